@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+//dateLayouts are tried in order when parsing a session's start/end times, covering both
+//RFC3339 ("2021-01-01T00:00:00Z") and RFC822 ("01 Jan 21 00:00 UTC") style timestamps.
+var dateLayouts = []string{time.RFC3339, time.RFC822}
+
+//BacktestConfig is the top level of a backtest.yaml file: which exchanges and symbols to
+//pull data for, and the session window to run the backtest over.
+type BacktestConfig struct {
+	Exchanges []ExchangeConfig `yaml:"exchanges"`
+	Session   SessionConfig    `yaml:"session"`
+}
+
+//ExchangeConfig selects a MarketDataSource and the symbols/interval to fetch from it.
+type ExchangeConfig struct {
+	Name     string   `yaml:"name"` //"binance", "bybit", "okx" or "csv"
+	CSVPath  string   `yaml:"csvPath,omitempty"`
+	Symbols  []string `yaml:"symbols"`
+	Interval string   `yaml:"interval"`
+}
+
+//SessionConfig is the time range a backtest is run over.
+type SessionConfig struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+//LoadConfig reads and parses a backtest.yaml file at path.
+func LoadConfig(path string) (*BacktestConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	var config BacktestConfig
+	if err := yaml.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+//StartTime parses Session.Start using the supported RFC3339/RFC822 layouts.
+func (s SessionConfig) StartTime() (time.Time, error) {
+	return parseSessionTime(s.Start)
+}
+
+//EndTime parses Session.End using the supported RFC3339/RFC822 layouts.
+func (s SessionConfig) EndTime() (time.Time, error) {
+	return parseSessionTime(s.End)
+}
+
+//NewSource builds the MarketDataSource described by an ExchangeConfig entry.
+func (e ExchangeConfig) NewSource() (MarketDataSource, error) {
+	switch e.Name {
+	case "binance":
+		return NewBinanceSource(), nil
+	case "bybit":
+		return NewBybitSource(), nil
+	case "okx":
+		return NewOKXSource(), nil
+	case "csv":
+		return &CSVSource{FilePath: e.CSVPath}, nil
+	default:
+		return nil, fmt.Errorf("config: unknown exchange %q", e.Name)
+	}
+}
+
+func parseSessionTime(value string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		} else {
+			lastErr = err
+		}
+	}
+	return time.Time{}, fmt.Errorf("config: time %q matches neither RFC3339 nor RFC822: %w", value, lastErr)
+}
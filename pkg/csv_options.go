@@ -0,0 +1,357 @@
+package pkg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//CSVOptions configures PricesFromCSVWithOptions: the delimiters in use, whether a header
+//row is present, how header names map onto the DataPoint struct fields, and an optional
+//row range to read.
+type CSVOptions struct {
+	FieldDelimiter  string //defaults to ","
+	RecordDelimiter string //defaults to "\n"
+	Comment         string //lines starting with this prefix are skipped; empty disables it. With the default RecordDelimiter only its first character is used, as is FieldDelimiter's
+
+	//HasHeader selects how csv-tagged fields are matched to columns. When true, the first
+	//record is consumed as a header and fields are matched to it by name (honoring
+	//ColumnAliases). When false, there is no header row at all: every record is data, and
+	//csv-tagged fields are matched to columns positionally, in the same order the fields are
+	//declared in the destination struct. ColumnAliases and TimestampColumn require a header
+	//to match names against, so they're only usable with HasHeader: true.
+	HasHeader bool
+
+	//ColumnAliases maps a DataPoint field's csv tag to the accepted header spellings for
+	//that column, e.g. "open": {"o", "open", "Open"}. A field with no entry falls back to
+	//its own tag name. Only used when HasHeader is true.
+	ColumnAliases map[string][]string
+
+	//TimestampColumn, when set, names the header (or alias) carrying the candle's timestamp.
+	//Only used when HasHeader is true.
+	TimestampColumn string
+	//TimestampLayout is the time.Parse layout used to parse TimestampColumn's values.
+	TimestampLayout string
+
+	//From and To bound which data rows (0-indexed, header excluded) are read; To == 0
+	//means "read to the end of the file".
+	From, To int
+}
+
+//defaultCSVOptions returns the delimiters PricesFromCSV has always assumed: comma-delimited,
+//newline-separated. applyCSVOptionDefaults falls back to these for any opts left unset.
+func defaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		FieldDelimiter:  ",",
+		RecordDelimiter: "\n",
+	}
+}
+
+//applyCSVOptionDefaults fills in FieldDelimiter/RecordDelimiter when opts leaves them unset
+//(the zero value would otherwise split on every character/byte) and validates that a
+//TimestampColumn always comes with a TimestampLayout to parse it with.
+func applyCSVOptionDefaults(opts CSVOptions) (CSVOptions, error) {
+	defaults := defaultCSVOptions()
+	if opts.FieldDelimiter == "" {
+		opts.FieldDelimiter = defaults.FieldDelimiter
+	}
+	if opts.RecordDelimiter == "" {
+		opts.RecordDelimiter = defaults.RecordDelimiter
+	}
+	if opts.TimestampColumn != "" && opts.TimestampLayout == "" {
+		return opts, fmt.Errorf("csv: TimestampColumn %q set without a TimestampLayout", opts.TimestampColumn)
+	}
+	return opts, nil
+}
+
+//PricesFromCSVWithOptions reads csvFilePath into a DataHandler the same way PricesFromCSV
+//does, but driven by opts: custom delimiters, arbitrary column order, header aliases and
+//extra columns (Date, Timestamp, Adj Close, Trades, ...), and a From/To row range.
+func PricesFromCSVWithOptions(csvFilePath string, opts CSVOptions) (*DataHandler, error) {
+	var prices []DataPoint
+	if err := DecodeCSVWithOptions(csvFilePath, opts, &prices); err != nil {
+		return nil, err
+	}
+	return newDataHandler(prices, 5), nil
+}
+
+//DecodeCSVWithOptions decodes csvFilePath into dest, a pointer to a slice of any struct type
+//whose fields carry `csv:"..."` tags naming the column they map to. With opts.HasHeader, that
+//match is against the file's header by name (honoring opts.ColumnAliases, case-insensitive),
+//and a struct field named Time (including one promoted from an embedded Event) receives
+//opts.TimestampColumn's value, parsed with opts.TimestampLayout; without it, tagged fields
+//are matched to columns positionally instead, in struct declaration order, and there is no
+//header row to strip. PricesFromCSVWithOptions is a thin wrapper around
+//this for the built-in DataPoint struct; callers with their own OHLCV-like struct can call
+//this directly instead.
+func DecodeCSVWithOptions(csvFilePath string, opts CSVOptions, dest interface{}) error {
+	opts, err := applyCSVOptionDefaults(opts)
+	if err != nil {
+		return err
+	}
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("csv: dest must be a pointer to a slice, got %T", dest)
+	}
+	sliceVal := destVal.Elem()
+	elemType := sliceVal.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("csv: dest slice element must be a struct, got %s", elemType.Kind())
+	}
+
+	records, err := readRecords(csvFilePath, opts)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	var mapping columnMapping
+	rows := records
+	if opts.HasHeader {
+		mapping, err = resolveColumnsByHeader(elemType, records[0], opts)
+		if err != nil {
+			return err
+		}
+		rows = records[1:]
+	} else {
+		if opts.TimestampColumn != "" {
+			return fmt.Errorf("csv: TimestampColumn %q requires a header row (set HasHeader: true)", opts.TimestampColumn)
+		}
+		mapping = resolveColumnsPositional(elemType)
+	}
+
+	if opts.To > 0 && opts.To < len(rows) {
+		rows = rows[:opts.To]
+	}
+	if opts.From > 0 {
+		if opts.From < len(rows) {
+			rows = rows[opts.From:]
+		} else {
+			rows = nil
+		}
+	}
+
+	for _, row := range rows {
+		elem := reflect.New(elemType).Elem()
+		if err := decodeRow(elem, row, mapping, opts.TimestampLayout); err != nil {
+			return err
+		}
+		sliceVal.Set(reflect.Append(sliceVal, elem))
+	}
+
+	return nil
+}
+
+//readRecords splits csvFilePath into records of fields. When opts.RecordDelimiter is the
+//default "\n", it's read with encoding/csv so quoted fields (embedded delimiters, escaped
+//quotes, even embedded newlines) are handled correctly. A non-default RecordDelimiter falls
+//back to splitRecords' plain-split behavior, since encoding/csv has no support for custom
+//record separators.
+func readRecords(path string, opts CSVOptions) ([][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("csv: opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if opts.RecordDelimiter != "\n" {
+		raw, err := io.ReadAll(file)
+		if err != nil {
+			return nil, fmt.Errorf("csv: reading %s: %w", path, err)
+		}
+		return splitRecords(string(raw), opts), nil
+	}
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	reader.Comma = []rune(opts.FieldDelimiter)[0]
+	reader.FieldsPerRecord = -1 //rows may be ragged; decodeRow bounds-checks each column access
+	reader.TrimLeadingSpace = true
+	if opts.Comment != "" {
+		reader.Comment = []rune(opts.Comment)[0]
+	}
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: reading %s: %w", path, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+//splitRecords breaks raw CSV content into fields per record using opts' delimiters,
+//dropping blank lines and comment lines. Used only for a non-default RecordDelimiter, where
+//encoding/csv can't be used; unlike it, this does no quote/escape handling.
+func splitRecords(raw string, opts CSVOptions) [][]string {
+	var records [][]string
+	for _, line := range strings.Split(raw, opts.RecordDelimiter) {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		if opts.Comment != "" && strings.HasPrefix(line, opts.Comment) {
+			continue
+		}
+
+		fields := strings.Split(line, opts.FieldDelimiter)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		records = append(records, fields)
+	}
+	return records
+}
+
+//csvField is one tagged struct field resolved against the CSV header: structIndex locates it
+//within the destination struct, columnIndex within a decoded row.
+type csvField struct {
+	structIndex int
+	columnIndex int
+	kind        reflect.Kind
+}
+
+//columnMapping is the result of matching a struct type's csv tags against a CSV header.
+//timestampIndex is -1 when opts.TimestampColumn is unset.
+type columnMapping struct {
+	fields         []csvField
+	timestampIndex int
+}
+
+//resolveColumnsByHeader matches every `csv`-tagged field of elemType (and, if set,
+//opts.TimestampColumn) against header, honoring opts.ColumnAliases and case-insensitive
+//comparison.
+func resolveColumnsByHeader(elemType reflect.Type, header []string, opts CSVOptions) (columnMapping, error) {
+	lowerHeader := make([]string, len(header))
+	for i, column := range header {
+		lowerHeader[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	mapping := columnMapping{timestampIndex: -1}
+	for i := 0; i < elemType.NumField(); i++ {
+		tag := elemType.Field(i).Tag.Get("csv")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		index, ok := findColumn(lowerHeader, tag, opts.ColumnAliases)
+		if !ok {
+			return columnMapping{}, fmt.Errorf("csv: no column found for required field %q (checked aliases %v)",
+				tag, opts.ColumnAliases[tag])
+		}
+		mapping.fields = append(mapping.fields, csvField{
+			structIndex: i,
+			columnIndex: index,
+			kind:        elemType.Field(i).Type.Kind(),
+		})
+	}
+
+	if opts.TimestampColumn != "" {
+		index, ok := findColumn(lowerHeader, opts.TimestampColumn, opts.ColumnAliases)
+		if !ok {
+			return columnMapping{}, fmt.Errorf("csv: no column found for TimestampColumn %q", opts.TimestampColumn)
+		}
+		mapping.timestampIndex = index
+	}
+
+	return mapping, nil
+}
+
+//resolveColumnsPositional matches every `csv`-tagged field of elemType to a column by its
+//declaration order: the first tagged field gets column 0, the second column 1, and so on.
+//Used when CSVOptions.HasHeader is false, since there's no header text to match names
+//against.
+func resolveColumnsPositional(elemType reflect.Type) columnMapping {
+	mapping := columnMapping{timestampIndex: -1}
+	columnIndex := 0
+	for i := 0; i < elemType.NumField(); i++ {
+		tag := elemType.Field(i).Tag.Get("csv")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		mapping.fields = append(mapping.fields, csvField{
+			structIndex: i,
+			columnIndex: columnIndex,
+			kind:        elemType.Field(i).Type.Kind(),
+		})
+		columnIndex++
+	}
+	return mapping
+}
+
+//findColumn looks for tagName or any of its configured aliases in header, case-insensitively.
+func findColumn(lowerHeader []string, tagName string, aliases map[string][]string) (int, bool) {
+	candidates := append([]string{tagName}, aliases[tagName]...)
+	for _, candidate := range candidates {
+		candidate = strings.ToLower(candidate)
+		for i, column := range lowerHeader {
+			if column == candidate {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+//decodeRow fills elem (a settable struct value of the type resolveColumnsByHeader or
+//resolveColumnsPositional built mapping from) from a single CSV row, parsing the timestamp
+//column (if resolveColumnsByHeader found one) with timestampLayout.
+func decodeRow(elem reflect.Value, row []string, mapping columnMapping, timestampLayout string) error {
+	for _, field := range mapping.fields {
+		if field.columnIndex >= len(row) {
+			return fmt.Errorf("csv: row %v has no column at index %d (header/row column-count mismatch)",
+				row, field.columnIndex)
+		}
+
+		raw := row[field.columnIndex]
+		target := elem.Field(field.structIndex)
+		switch field.kind {
+		case reflect.Float32, reflect.Float64:
+			value, err := strToFloat(raw)
+			if err != nil {
+				return err
+			}
+			target.SetFloat(value)
+		case reflect.String:
+			target.SetString(raw)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+			if err != nil {
+				return fmt.Errorf("csv: parsing %q as int: %w", raw, err)
+			}
+			target.SetInt(value)
+		default:
+			return fmt.Errorf("csv: unsupported field kind %s for a csv-tagged column", field.kind)
+		}
+	}
+
+	if mapping.timestampIndex >= 0 {
+		if mapping.timestampIndex >= len(row) {
+			return fmt.Errorf("csv: row %v has no column at index %d for TimestampColumn",
+				row, mapping.timestampIndex)
+		}
+
+		raw := row[mapping.timestampIndex]
+		timestamp, err := time.Parse(timestampLayout, raw)
+		if err != nil {
+			return fmt.Errorf("csv: parsing timestamp %q with layout %q: %w", raw, timestampLayout, err)
+		}
+		if timeField := elem.FieldByName("Time"); timeField.IsValid() && timeField.CanSet() {
+			timeField.Set(reflect.ValueOf(timestamp))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTempCSV(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing temp csv: %v", err)
+	}
+	return path
+}
+
+func TestPricesFromCSVWithOptionsHeaderPresent(t *testing.T) {
+	path := writeTempCSV(t, "open,high,low,close,volume\n1,2,0.5,1.5,100\n")
+
+	handler, err := PricesFromCSVWithOptions(path, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("PricesFromCSVWithOptions: %v", err)
+	}
+	if got, want := len(handler.prices), 1; got != want {
+		t.Fatalf("len(prices) = %d, want %d", got, want)
+	}
+	if handler.prices[0].Open != 1 || handler.prices[0].Volume != 100 {
+		t.Errorf("decoded row = %+v, want Open=1 Volume=100", handler.prices[0])
+	}
+}
+
+//TestPricesFromCSVWithOptionsHeaderless covers the zero-value/HasHeader:false case: every
+//record, including the first, is data, and csv-tagged fields match columns by declaration
+//order (Open, High, Low, Close, Volume) instead of by header name.
+func TestPricesFromCSVWithOptionsHeaderless(t *testing.T) {
+	path := writeTempCSV(t, "1,2,0.5,1.5,100\n3,4,2.5,3.5,200\n")
+
+	handler, err := PricesFromCSVWithOptions(path, CSVOptions{})
+	if err != nil {
+		t.Fatalf("PricesFromCSVWithOptions: %v", err)
+	}
+	if got, want := len(handler.prices), 2; got != want {
+		t.Fatalf("len(prices) = %d, want %d", got, want)
+	}
+	if handler.prices[0].Open != 1 || handler.prices[0].Volume != 100 {
+		t.Errorf("decoded row 0 = %+v, want Open=1 Volume=100", handler.prices[0])
+	}
+	if handler.prices[1].Open != 3 || handler.prices[1].Volume != 200 {
+		t.Errorf("decoded row 1 = %+v, want Open=3 Volume=200", handler.prices[1])
+	}
+}
+
+func TestPricesFromCSVWithOptionsHeaderlessRejectsTimestampColumn(t *testing.T) {
+	path := writeTempCSV(t, "1,2,0.5,1.5,100\n")
+
+	_, err := PricesFromCSVWithOptions(path, CSVOptions{
+		TimestampColumn: "time",
+		TimestampLayout: time.RFC3339,
+	})
+	if err == nil {
+		t.Fatal("expected an error: TimestampColumn has no header to match by name without HasHeader: true")
+	}
+}
+
+func TestPricesFromCSVWithOptionsRaggedRowErrors(t *testing.T) {
+	path := writeTempCSV(t, "open,high,low,close,volume\n1,2,0.5,1.5\n")
+
+	if _, err := PricesFromCSVWithOptions(path, CSVOptions{HasHeader: true}); err == nil {
+		t.Fatal("expected an error decoding a row missing its volume column, got nil")
+	}
+}
+
+func TestPricesFromCSVWithOptionsQuotedFieldWithDelimiter(t *testing.T) {
+	path := writeTempCSV(t, "open,high,low,close,volume,note\n1,2,0.5,1.5,100,\"a, quoted note\"\n")
+
+	handler, err := PricesFromCSVWithOptions(path, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("PricesFromCSVWithOptions: %v", err)
+	}
+	if got, want := len(handler.prices), 1; got != want {
+		t.Fatalf("len(prices) = %d, want %d", got, want)
+	}
+	if handler.prices[0].Volume != 100 {
+		t.Errorf("Volume = %v, want 100 (quoted note column should not have shifted columns)", handler.prices[0].Volume)
+	}
+}
+
+func TestPricesFromCSVWithOptionsTimestampColumn(t *testing.T) {
+	path := writeTempCSV(t, "time,open,high,low,close,volume\n2024-01-01T00:00:00Z,1,2,0.5,1.5,100\n")
+
+	handler, err := PricesFromCSVWithOptions(path, CSVOptions{
+		HasHeader:       true,
+		TimestampColumn: "time",
+		TimestampLayout: time.RFC3339,
+	})
+	if err != nil {
+		t.Fatalf("PricesFromCSVWithOptions: %v", err)
+	}
+	want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !handler.prices[0].Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", handler.prices[0].Time, want)
+	}
+}
+
+//customCandle exercises DecodeCSVWithOptions against a caller-supplied struct instead of
+//the built-in DataPoint, per the request's "decode into your own DataPoint-like struct" ask.
+type customCandle struct {
+	Symbol string  `csv:"symbol"`
+	Open   float64 `csv:"open"`
+	Trades int64   `csv:"trades"`
+}
+
+func TestDecodeCSVWithOptionsCustomStruct(t *testing.T) {
+	path := writeTempCSV(t, "symbol,open,trades\nBTCUSDT,42000.5,37\n")
+
+	var candles []customCandle
+	if err := DecodeCSVWithOptions(path, CSVOptions{HasHeader: true}, &candles); err != nil {
+		t.Fatalf("DecodeCSVWithOptions: %v", err)
+	}
+	if len(candles) != 1 {
+		t.Fatalf("len(candles) = %d, want 1", len(candles))
+	}
+	want := customCandle{Symbol: "BTCUSDT", Open: 42000.5, Trades: 37}
+	if candles[0] != want {
+		t.Errorf("decoded candle = %+v, want %+v", candles[0], want)
+	}
+}
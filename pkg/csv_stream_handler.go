@@ -0,0 +1,249 @@
+package pkg
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//CSVStreamHandler is a DataIterator that reads rows lazily from one or more CSV files
+//matched by glob patterns (including "**" for recursive matches), processed in lexical
+//order, so a multi-year candle history can be backtested without loading it all at once.
+type CSVStreamHandler struct {
+	ctx        context.Context
+	windowSize int
+	files      []string
+	fileIdx    int
+
+	currentFile   *os.File
+	currentReader *csv.Reader
+
+	window   []DataPoint
+	rowsSeen int
+	offset   int
+	err      error
+
+	observer TickObserver
+}
+
+//NewCSVStreamHandler resolves patterns into a sorted list of CSV files and returns a
+//CSVStreamHandler ready to stream AggregatedDataPoints windows of size windowSize from
+//them. offset skips the first offset data rows across the combined file set, which lets a
+//long backtest resume where a previous run left off.
+func NewCSVStreamHandler(ctx context.Context, patterns []string, windowSize, offset int) (*CSVStreamHandler, error) {
+	files, err := expandGlobPatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("csv stream: no files matched patterns %v", patterns)
+	}
+
+	return &CSVStreamHandler{
+		ctx:        ctx,
+		windowSize: windowSize,
+		files:      files,
+		offset:     offset,
+	}, nil
+}
+
+//SetTickObserver registers observer to be called on every subsequent Next window.
+func (h *CSVStreamHandler) SetTickObserver(observer TickObserver) {
+	h.observer = observer
+}
+
+//Next returns the next rolling window of windowSize candles. The first call primes the
+//window by reading windowSize rows; every call after that reads exactly one new row and
+//slides the window forward, so the stream actually advances instead of re-returning the
+//same window forever.
+func (h *CSVStreamHandler) Next() (*AggregatedDataPoints, bool) {
+	if len(h.window) < h.windowSize {
+		for len(h.window) < h.windowSize {
+			point, ok := h.readNextDataPoint()
+			if !ok {
+				return nil, false
+			}
+			h.window = append(h.window, point)
+		}
+	} else {
+		point, ok := h.readNextDataPoint()
+		if !ok {
+			return nil, false
+		}
+		h.window = append(h.window[1:], point)
+	}
+
+	windowCopy := make([]DataPoint, len(h.window))
+	copy(windowCopy, h.window)
+	data := &AggregatedDataPoints{datapoints: windowCopy}
+	if h.observer != nil {
+		h.observer.OnTick(data)
+	}
+	return data, true
+}
+
+//readNextDataPoint returns the next data row past h.offset, skipping over offset rows at
+//the start of the combined file set and bailing out early if h.ctx is cancelled.
+func (h *CSVStreamHandler) readNextDataPoint() (DataPoint, bool) {
+	for {
+		if ctxDone(h.ctx) {
+			return DataPoint{}, false
+		}
+
+		point, ok := h.nextDataPoint()
+		if !ok {
+			return DataPoint{}, false
+		}
+
+		h.rowsSeen++
+		if h.rowsSeen <= h.offset {
+			continue
+		}
+
+		return point, true
+	}
+}
+
+//Err returns the first error encountered while iterating, if any.
+func (h *CSVStreamHandler) Err() error {
+	return h.err
+}
+
+//Close releases the currently open file handle, if any.
+func (h *CSVStreamHandler) Close() error {
+	if h.currentFile != nil {
+		return h.currentFile.Close()
+	}
+	return nil
+}
+
+//nextDataPoint reads the next OHLCV row from the current file, opening (and validating the
+//header of) the next file in h.files once the current one is exhausted.
+func (h *CSVStreamHandler) nextDataPoint() (DataPoint, bool) {
+	for {
+		if h.currentReader == nil {
+			if !h.openNextFile() {
+				return DataPoint{}, false
+			}
+		}
+
+		line, err := h.currentReader.Read()
+		if err == io.EOF {
+			h.currentFile.Close()
+			h.currentFile = nil
+			h.currentReader = nil
+			continue
+		}
+		if err != nil {
+			h.err = fmt.Errorf("csv stream: reading %s: %w", h.files[h.fileIdx-1], err)
+			return DataPoint{}, false
+		}
+
+		var numbers [5]float64
+		for i := 0; i < 5; i++ {
+			value, err := strToFloat(line[i])
+			if err != nil {
+				h.err = err
+				return DataPoint{}, false
+			}
+			numbers[i] = value
+		}
+
+		return DataPoint{
+			Open:   numbers[0],
+			High:   numbers[1],
+			Low:    numbers[2],
+			Close:  numbers[3],
+			Volume: numbers[4],
+		}, true
+	}
+}
+
+//openNextFile opens the next file in h.files, wires up its csv.Reader and validates its
+//header, advancing h.fileIdx. It returns false once every file has been consumed.
+func (h *CSVStreamHandler) openNextFile() bool {
+	if h.fileIdx >= len(h.files) {
+		return false
+	}
+
+	file, err := os.Open(h.files[h.fileIdx])
+	if err != nil {
+		h.err = fmt.Errorf("csv stream: opening %s: %w", h.files[h.fileIdx], err)
+		return false
+	}
+	h.fileIdx++
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	if line, err := reader.Read(); err != nil || !isCSVHeaderValid(line) {
+		file.Close()
+		h.err = fmt.Errorf("csv stream: invalid header in %s", h.files[h.fileIdx-1])
+		return false
+	}
+
+	h.currentFile = file
+	h.currentReader = reader
+	return true
+}
+
+//expandGlobPatterns resolves patterns (plain globs or "**"-recursive ones) to a sorted,
+//deduplicated list of matching file paths.
+func expandGlobPatterns(patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+
+	for _, pattern := range patterns {
+		matches, err := expandGlobPattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("csv stream: expanding pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+//expandGlobPattern resolves a single pattern, walking the filesystem for "**" patterns
+//(filepath.Glob has no recursive-match support) and delegating to filepath.Glob otherwise.
+func expandGlobPattern(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	prefix, suffix, _ := strings.Cut(pattern, "**")
+	root := filepath.Clean(prefix)
+	suffix = strings.TrimPrefix(suffix, "/")
+
+	var matches []string
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if ok, err := filepath.Match(suffix, filepath.Base(relative)); err != nil {
+			return err
+		} else if ok {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	return matches, err
+}
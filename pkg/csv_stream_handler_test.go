@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type countingObserver struct {
+	ticks int
+}
+
+func (o *countingObserver) OnTick(data *AggregatedDataPoints) {
+	o.ticks++
+}
+
+func TestCSVStreamHandlerFiresTickObserver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	contents := "Open,High,Low,Close,Volume\n1,1,1,1,1\n2,2,2,2,2\n3,3,3,3,3\n4,4,4,4,4\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing temp csv: %v", err)
+	}
+
+	handler, err := NewCSVStreamHandler(context.Background(), []string{path}, 2, 0)
+	if err != nil {
+		t.Fatalf("NewCSVStreamHandler: %v", err)
+	}
+	defer handler.Close()
+
+	observer := &countingObserver{}
+	handler.SetTickObserver(observer)
+
+	windows := 0
+	for {
+		if _, ok := handler.Next(); !ok {
+			break
+		}
+		windows++
+	}
+
+	if observer.ticks != windows {
+		t.Errorf("observer saw %d ticks, want %d (one per window)", observer.ticks, windows)
+	}
+	if observer.ticks == 0 {
+		t.Fatal("expected at least one tick to be observed")
+	}
+}
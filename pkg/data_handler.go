@@ -15,16 +15,34 @@ import (
 type DataHandler struct {
 	counter, windowSize int
 	prices              []DataPoint
+	observer            TickObserver
 }
 
-//DataPoint is a unit that encapsulates OHLCV price data
+//TickObserver is notified with every AggregatedDataPoints window produced by nextValues,
+//e.g. to track equity/unrealized PnL for a report.SummaryReport as the backtest runs.
+type TickObserver interface {
+	OnTick(data *AggregatedDataPoints)
+}
+
+//SetTickObserver registers observer to be called on every subsequent nextValues tick.
+func (handler *DataHandler) SetTickObserver(observer TickObserver) {
+	handler.observer = observer
+}
+
+//DataPoint is a unit that encapsulates OHLCV price data. The csv tags are used by
+//PricesFromCSVWithOptions to map CSV columns onto these fields.
 type DataPoint struct {
 	Event
-	Open, High, Low, Close, Volume float64
+	Open   float64 `csv:"open"`
+	High   float64 `csv:"high"`
+	Low    float64 `csv:"low"`
+	Close  float64 `csv:"close"`
+	Volume float64 `csv:"volume"`
 }
 
 //Position is the representation of a traded position
 type Position struct {
+	Symbol                 string
 	Direction              Direction
 	Size                   float64 //total size of the position including leverage
 	Leverage               uint    //the multiplier for increasing the total traded position
@@ -43,6 +61,11 @@ type AggregatedDataPoints struct {
 	datapoints []DataPoint
 }
 
+//Latest returns the most recent DataPoint in this window.
+func (data *AggregatedDataPoints) Latest() DataPoint {
+	return data.datapoints[len(data.datapoints)-1]
+}
+
 //Required columns in the CSV file
 var csvColumns = []string{"open", "high", "low", "close", "volume"}
 
@@ -63,6 +86,9 @@ func (handler *DataHandler) nextValues() *AggregatedDataPoints {
 			datapoints: handler.prices[handler.counter-handler.windowSize : handler.counter],
 		}
 		handler.counter++
+		if handler.observer != nil {
+			handler.observer.OnTick(data)
+		}
 		return data
 	}
 	return nil
@@ -70,7 +96,12 @@ func (handler *DataHandler) nextValues() *AggregatedDataPoints {
 
 //LoadPricesFromCSV reads all csv data in the OHLCV format to the DataHandler and returns if a error occurred
 func PricesFromCSV(csvFilePath string) (*DataHandler, error) {
-	csvFile, _ := os.Open(csvFilePath)
+	csvFile, err := os.Open(csvFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening csv file %q: %w", csvFilePath, err)
+	}
+	defer csvFile.Close()
+
 	reader := csv.NewReader(bufio.NewReader(csvFile))
 
 	//Reading first line header and validating the required columns
@@ -0,0 +1,33 @@
+package pkg
+
+import (
+	"context"
+)
+
+//DataIterator streams AggregatedDataPoints windows one at a time instead of holding the
+//full price series in memory, so very long backtests don't have to fit in RAM.
+type DataIterator interface {
+	//Next returns the next rolling window of candles, or ok=false once the iterator is
+	//exhausted, its context is cancelled, or an unrecoverable read error occurred (check
+	//Err() in that case).
+	Next() (data *AggregatedDataPoints, ok bool)
+	//Err returns the first error encountered while iterating, if any.
+	Err() error
+	//Close releases any open file handles held by the iterator.
+	Close() error
+	//SetTickObserver registers observer to be notified with every window Next produces,
+	//mirroring DataHandler.SetTickObserver so PositionMarker and ReportCollector compose
+	//with the streaming path the same way they do with DataHandler.
+	SetTickObserver(observer TickObserver)
+}
+
+//ctxDone is a small helper shared by DataIterator implementations to bail out of Next
+//as soon as ctx is cancelled.
+func ctxDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"context"
+	"time"
+)
+
+//MarketDataSource abstracts a provider of OHLCV candles, either fetched in bulk
+//for a historical range or streamed live. CSVSource and the exchange clients in
+//exchange_*.go all satisfy this interface so a DataHandler can be built from any of them.
+type MarketDataSource interface {
+	//Fetch retrieves every candle for symbol/interval between start and end.
+	Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error)
+	//Stream pushes newly closed candles onto the returned channel until ctx is cancelled,
+	//at which point the channel is closed.
+	Stream(ctx context.Context) <-chan DataPoint
+}
+
+//CSVSource adapts the existing CSV loader to the MarketDataSource interface so it can
+//be used interchangeably with the exchange-backed sources.
+type CSVSource struct {
+	FilePath string
+}
+
+//Fetch ignores symbol/interval/start/end and returns every row found in FilePath,
+//since a plain CSV file carries no symbol or interval metadata of its own.
+func (s *CSVSource) Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error) {
+	handler, err := PricesFromCSV(s.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	return handler.prices, nil
+}
+
+//Stream is not supported for CSV files: the returned channel is closed immediately.
+func (s *CSVSource) Stream(ctx context.Context) <-chan DataPoint {
+	ch := make(chan DataPoint)
+	close(ch)
+	return ch
+}
+
+//NewDataHandlerFromSource fetches symbol/interval candles for [start, end) from source,
+//caching the result when source is wrapped with NewCachedSource, and returns a
+//DataHandler ready to run a backtest over them.
+func NewDataHandlerFromSource(source MarketDataSource, symbol, interval string, start, end time.Time, windowSize int) (*DataHandler, error) {
+	prices, err := source.Fetch(symbol, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return newDataHandler(prices, windowSize), nil
+}
@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//CachedSource wraps a MarketDataSource and persists every Fetch result to disk, keyed by
+//(exchange, symbol, interval, range), so repeated backtests don't re-hit the API.
+type CachedSource struct {
+	Source   MarketDataSource
+	Exchange string //used only to namespace cache entries, e.g. "binance"
+	CacheDir string
+}
+
+//NewCachedSource returns a CachedSource that caches source's Fetch results under cacheDir.
+func NewCachedSource(source MarketDataSource, exchange, cacheDir string) *CachedSource {
+	return &CachedSource{Source: source, Exchange: exchange, CacheDir: cacheDir}
+}
+
+//Fetch returns the cached candles for symbol/interval/[start,end) when present, otherwise
+//delegates to the wrapped source and writes the result to the cache before returning.
+func (c *CachedSource) Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error) {
+	path := c.cachePath(symbol, interval, start, end)
+
+	if prices, err := readCacheFile(path); err == nil {
+		return prices, nil
+	}
+
+	prices, err := c.Source.Fetch(symbol, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCacheFile(path, prices); err != nil {
+		return nil, fmt.Errorf("datasource cache: writing %s: %w", path, err)
+	}
+
+	return prices, nil
+}
+
+//Stream is passed straight through to the wrapped source: live data isn't cached.
+func (c *CachedSource) Stream(ctx context.Context) <-chan DataPoint {
+	return c.Source.Stream(ctx)
+}
+
+//cachePath derives a deterministic on-disk cache key from exchange, symbol, interval and range.
+func (c *CachedSource) cachePath(symbol, interval string, start, end time.Time) string {
+	key := fmt.Sprintf("%s|%s|%s|%d|%d", c.Exchange, symbol, interval, start.Unix(), end.Unix())
+	hash := sha1.Sum([]byte(key))
+	return filepath.Join(c.CacheDir, fmt.Sprintf("%x.gob", hash))
+}
+
+//readCacheFile decodes a gob-encoded []DataPoint previously written by writeCacheFile.
+func readCacheFile(path string) ([]DataPoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var prices []DataPoint
+	if err := gob.NewDecoder(file).Decode(&prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+//writeCacheFile gob-encodes prices to path, creating the parent directory if needed.
+func writeCacheFile(path string, prices []DataPoint) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return gob.NewEncoder(file).Encode(prices)
+}
@@ -0,0 +1,18 @@
+package pkg
+
+import "time"
+
+//Event carries the timestamp a DataPoint/AggregatedDataPoints was observed at.
+type Event struct {
+	Time time.Time
+}
+
+//Direction is the side of a traded Position.
+type Direction int
+
+const (
+	//Long is a position that profits when price rises.
+	Long Direction = iota
+	//Short is a position that profits when price falls.
+	Short
+)
@@ -0,0 +1,108 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//binanceKlineURL is Binance's public, unauthenticated spot kline endpoint.
+const binanceKlineURL = "https://api.binance.com/api/v3/klines"
+
+//BinanceSource fetches OHLCV candles from Binance's public kline REST API.
+type BinanceSource struct {
+	HTTPClient *http.Client
+}
+
+//NewBinanceSource returns a BinanceSource using http.DefaultClient.
+func NewBinanceSource() *BinanceSource {
+	return &BinanceSource{HTTPClient: http.DefaultClient}
+}
+
+//Fetch retrieves every candle for symbol/interval between start and end, paging through
+//Binance's 1000-row-per-request limit.
+func (s *BinanceSource) Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error) {
+	var prices []DataPoint
+	cursor := start
+
+	for cursor.Before(end) {
+		url := fmt.Sprintf("%s?symbol=%s&interval=%s&startTime=%d&endTime=%d&limit=1000",
+			binanceKlineURL, symbol, interval, cursor.UnixMilli(), end.UnixMilli())
+
+		resp, err := s.HTTPClient.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("binance: fetching klines for %s: %w", symbol, err)
+		}
+
+		var rows [][]interface{}
+		decodeErr := json.NewDecoder(resp.Body).Decode(&rows)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("binance: decoding klines for %s: %w", symbol, decodeErr)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			point, err := binanceRowToDataPoint(row)
+			if err != nil {
+				return nil, err
+			}
+			prices = append(prices, point)
+		}
+
+		lastOpenTime := int64(rows[len(rows)-1][0].(float64))
+		cursor = time.UnixMilli(lastOpenTime + 1)
+	}
+
+	return prices, nil
+}
+
+//Stream is not yet implemented for Binance; the returned channel is closed immediately.
+func (s *BinanceSource) Stream(ctx context.Context) <-chan DataPoint {
+	ch := make(chan DataPoint)
+	close(ch)
+	return ch
+}
+
+//binanceRowToDataPoint converts a single [openTime, open, high, low, close, volume, ...] kline row.
+func binanceRowToDataPoint(row []interface{}) (DataPoint, error) {
+	if len(row) < 6 {
+		return DataPoint{}, fmt.Errorf("binance: malformed kline row %v", row)
+	}
+
+	openTime := int64(row[0].(float64))
+
+	open, err := strToFloat(row[1].(string))
+	if err != nil {
+		return DataPoint{}, err
+	}
+	high, err := strToFloat(row[2].(string))
+	if err != nil {
+		return DataPoint{}, err
+	}
+	low, err := strToFloat(row[3].(string))
+	if err != nil {
+		return DataPoint{}, err
+	}
+	closePrice, err := strToFloat(row[4].(string))
+	if err != nil {
+		return DataPoint{}, err
+	}
+	volume, err := strToFloat(row[5].(string))
+	if err != nil {
+		return DataPoint{}, err
+	}
+
+	return DataPoint{
+		Event:  Event{Time: time.UnixMilli(openTime)},
+		Open:   open,
+		High:   high,
+		Low:    low,
+		Close:  closePrice,
+		Volume: volume,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//bybitKlineURL is Bybit's public, unauthenticated kline endpoint for the linear (USDT) category.
+const bybitKlineURL = "https://api.bybit.com/v5/market/kline"
+
+//BybitSource fetches OHLCV candles from Bybit's public kline REST API.
+type BybitSource struct {
+	HTTPClient *http.Client
+}
+
+//NewBybitSource returns a BybitSource using http.DefaultClient.
+func NewBybitSource() *BybitSource {
+	return &BybitSource{HTTPClient: http.DefaultClient}
+}
+
+type bybitKlineResponse struct {
+	Result struct {
+		List [][]string `json:"list"`
+	} `json:"result"`
+}
+
+//Fetch retrieves every candle for symbol/interval between start and end, paging through
+//Bybit's 1000-row-per-request limit. Bybit returns rows newest-first, so they're reversed
+//before being appended.
+func (s *BybitSource) Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error) {
+	var prices []DataPoint
+	cursor := start
+
+	for cursor.Before(end) {
+		url := fmt.Sprintf("%s?category=linear&symbol=%s&interval=%s&start=%d&end=%d&limit=1000",
+			bybitKlineURL, symbol, interval, cursor.UnixMilli(), end.UnixMilli())
+
+		resp, err := s.HTTPClient.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("bybit: fetching klines for %s: %w", symbol, err)
+		}
+
+		var parsed bybitKlineResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("bybit: decoding klines for %s: %w", symbol, decodeErr)
+		}
+		if len(parsed.Result.List) == 0 {
+			break
+		}
+
+		for i := len(parsed.Result.List) - 1; i >= 0; i-- {
+			point, err := bybitRowToDataPoint(parsed.Result.List[i])
+			if err != nil {
+				return nil, err
+			}
+			prices = append(prices, point)
+		}
+
+		lastRow := parsed.Result.List[0]
+		lastOpenTime, err := strToFloat(lastRow[0])
+		if err != nil {
+			return nil, err
+		}
+		cursor = time.UnixMilli(int64(lastOpenTime) + 1)
+	}
+
+	return prices, nil
+}
+
+//Stream is not yet implemented for Bybit; the returned channel is closed immediately.
+func (s *BybitSource) Stream(ctx context.Context) <-chan DataPoint {
+	ch := make(chan DataPoint)
+	close(ch)
+	return ch
+}
+
+//bybitRowToDataPoint converts a single [start, open, high, low, close, volume, turnover] kline row.
+func bybitRowToDataPoint(row []string) (DataPoint, error) {
+	if len(row) < 6 {
+		return DataPoint{}, fmt.Errorf("bybit: malformed kline row %v", row)
+	}
+
+	openTime, err := strToFloat(row[0])
+	if err != nil {
+		return DataPoint{}, err
+	}
+
+	open, err := strToFloat(row[1])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	high, err := strToFloat(row[2])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	low, err := strToFloat(row[3])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	closePrice, err := strToFloat(row[4])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	volume, err := strToFloat(row[5])
+	if err != nil {
+		return DataPoint{}, err
+	}
+
+	return DataPoint{
+		Event:  Event{Time: time.UnixMilli(int64(openTime))},
+		Open:   open,
+		High:   high,
+		Low:    low,
+		Close:  closePrice,
+		Volume: volume,
+	}, nil
+}
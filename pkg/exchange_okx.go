@@ -0,0 +1,123 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//okxKlineURL is OKX's public, unauthenticated candlestick endpoint.
+const okxKlineURL = "https://www.okx.com/api/v5/market/history-candles"
+
+//OKXSource fetches OHLCV candles from OKX's public candlestick REST API.
+type OKXSource struct {
+	HTTPClient *http.Client
+}
+
+//NewOKXSource returns an OKXSource using http.DefaultClient.
+func NewOKXSource() *OKXSource {
+	return &OKXSource{HTTPClient: http.DefaultClient}
+}
+
+type okxKlineResponse struct {
+	Data [][]string `json:"data"`
+}
+
+//Fetch retrieves every candle for symbol/interval between start and end, paging through
+//OKX's 100-row-per-request limit. OKX returns rows newest-first, so they're reversed
+//before being appended. Unlike Binance/Bybit's startTime/endTime, OKX's "after"/"before"
+//params are the other way round: "before" asks for candles newer than the given ts (what
+//pages forward), "after" asks for candles older than it, so "before" bounds the forward
+//cursor and "after" bounds the backtest's end.
+func (s *OKXSource) Fetch(symbol, interval string, start, end time.Time) ([]DataPoint, error) {
+	var prices []DataPoint
+	cursor := start
+
+	for cursor.Before(end) {
+		url := fmt.Sprintf("%s?instId=%s&bar=%s&before=%d&after=%d&limit=100",
+			okxKlineURL, symbol, interval, cursor.UnixMilli(), end.UnixMilli())
+
+		resp, err := s.HTTPClient.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("okx: fetching klines for %s: %w", symbol, err)
+		}
+
+		var parsed okxKlineResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("okx: decoding klines for %s: %w", symbol, decodeErr)
+		}
+		if len(parsed.Data) == 0 {
+			break
+		}
+
+		for i := len(parsed.Data) - 1; i >= 0; i-- {
+			point, err := okxRowToDataPoint(parsed.Data[i])
+			if err != nil {
+				return nil, err
+			}
+			prices = append(prices, point)
+		}
+
+		lastRow := parsed.Data[0]
+		lastOpenTime, err := strToFloat(lastRow[0])
+		if err != nil {
+			return nil, err
+		}
+		cursor = time.UnixMilli(int64(lastOpenTime) + 1)
+	}
+
+	return prices, nil
+}
+
+//Stream is not yet implemented for OKX; the returned channel is closed immediately.
+func (s *OKXSource) Stream(ctx context.Context) <-chan DataPoint {
+	ch := make(chan DataPoint)
+	close(ch)
+	return ch
+}
+
+//okxRowToDataPoint converts a single [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm] candle row.
+func okxRowToDataPoint(row []string) (DataPoint, error) {
+	if len(row) < 6 {
+		return DataPoint{}, fmt.Errorf("okx: malformed candle row %v", row)
+	}
+
+	openTime, err := strToFloat(row[0])
+	if err != nil {
+		return DataPoint{}, err
+	}
+
+	open, err := strToFloat(row[1])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	high, err := strToFloat(row[2])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	low, err := strToFloat(row[3])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	closePrice, err := strToFloat(row[4])
+	if err != nil {
+		return DataPoint{}, err
+	}
+	volume, err := strToFloat(row[5])
+	if err != nil {
+		return DataPoint{}, err
+	}
+
+	return DataPoint{
+		Event:  Event{Time: time.UnixMilli(int64(openTime))},
+		Open:   open,
+		High:   high,
+		Low:    low,
+		Close:  closePrice,
+		Volume: volume,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package pkg
+
+//Ready-made MarginModel/FeeSchedule configs for exchanges this package ships a
+//MarketDataSource for, so callers don't have to hand-tune maintenance margin rates and fee
+//tiers themselves. Rates are the publicly documented defaults as of this package's
+//introduction and should be revisited if an exchange changes them.
+
+//BinanceUSDTMMarginModel returns the isolated-margin model for Binance's USDT-M perpetual
+//futures, using their base-tier 0.4% maintenance margin rate.
+func BinanceUSDTMMarginModel() MarginModel {
+	return IsolatedMarginModel{MaintenanceMarginRate: 0.004}
+}
+
+//BinanceUSDTMFeeSchedule returns Binance USDT-M futures' regular-user fee schedule
+//(0.02%/0.04% maker/taker) with its VIP volume tiers.
+func BinanceUSDTMFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		MakerBps: 2,
+		TakerBps: 4,
+		Tiers: []VolumeTier{
+			{Volume30d: 250_000, MakerBps: 1.6, TakerBps: 4},
+			{Volume30d: 1_000_000, MakerBps: 1.4, TakerBps: 3.5},
+			{Volume30d: 5_000_000, MakerBps: 1.2, TakerBps: 3.2},
+		},
+	}
+}
+
+//BybitInverseMarginModel returns the isolated-margin model for Bybit's inverse perpetuals,
+//using their base-tier 0.5% maintenance margin rate.
+func BybitInverseMarginModel() MarginModel {
+	return IsolatedMarginModel{MaintenanceMarginRate: 0.005}
+}
+
+//BybitInverseFeeSchedule returns Bybit inverse perpetuals' regular-user fee schedule
+//(0.01%/0.06% maker/taker).
+func BybitInverseFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		MakerBps: 1,
+		TakerBps: 6,
+	}
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+//roundTripFunc lets a test stand in as an http.Client's Transport without a real server or
+//network access.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestBinanceFetchPagesForwardAndSetsTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC)
+
+	requests := 0
+	source := NewBinanceSource()
+	source.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if requests > 1 {
+			return jsonResponse(`[]`), nil
+		}
+
+		query := req.URL.Query()
+		if query.Get("startTime") != strconv.FormatInt(start.UnixMilli(), 10) {
+			t.Errorf("startTime = %q, want %d", query.Get("startTime"), start.UnixMilli())
+		}
+		ts := strconv.FormatInt(start.UnixMilli(), 10)
+		return jsonResponse(`[[` + ts + `,"1","2","0.5","1.5","100"]]`), nil
+	})}
+
+	prices, err := source.Fetch("BTCUSDT", "1m", start, end)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(prices) != 1 {
+		t.Fatalf("len(prices) = %d, want 1", len(prices))
+	}
+	if !prices[0].Time.Equal(start) {
+		t.Errorf("Time = %v, want %v", prices[0].Time, start)
+	}
+}
+
+func TestBybitFetchPagesForwardAndSetsTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC)
+
+	requests := 0
+	source := NewBybitSource()
+	source.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if requests > 1 {
+			return jsonResponse(`{"result":{"list":[]}}`), nil
+		}
+
+		query := req.URL.Query()
+		if query.Get("start") != strconv.FormatInt(start.UnixMilli(), 10) {
+			t.Errorf("start = %q, want %d", query.Get("start"), start.UnixMilli())
+		}
+		ts := strconv.FormatInt(start.UnixMilli(), 10)
+		return jsonResponse(`{"result":{"list":[["` + ts + `","1","2","0.5","1.5","100","0"]]}}`), nil
+	})}
+
+	prices, err := source.Fetch("BTCUSDT", "1", start, end)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(prices) != 1 {
+		t.Fatalf("len(prices) = %d, want 1", len(prices))
+	}
+	if !prices[0].Time.Equal(start) {
+		t.Errorf("Time = %v, want %v", prices[0].Time, start)
+	}
+}
+
+//TestOKXFetchPagesForwardWithinRange pins down the pagination bug this source used to have:
+//"before" (not "after") is what pages forward from cursor, and "after" must bound the
+//request by end, or the very first request asks OKX for candles before start instead of
+//within [start, end).
+func TestOKXFetchPagesForwardWithinRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC)
+
+	requests := 0
+	source := NewOKXSource()
+	source.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if requests > 1 {
+			return jsonResponse(`{"data":[]}`), nil
+		}
+
+		query := req.URL.Query()
+		if query.Get("before") != strconv.FormatInt(start.UnixMilli(), 10) {
+			t.Errorf("before = %q, want %d (the forward-paging cursor)", query.Get("before"), start.UnixMilli())
+		}
+		if query.Get("after") != strconv.FormatInt(end.UnixMilli(), 10) {
+			t.Errorf("after = %q, want %d (the backtest's end bound)", query.Get("after"), end.UnixMilli())
+		}
+
+		ts := strconv.FormatInt(start.UnixMilli(), 10)
+		return jsonResponse(`{"data":[["` + ts + `","1","2","0.5","1.5","100","0","0","1"]]}`), nil
+	})}
+
+	prices, err := source.Fetch("BTC-USDT", "1m", start, end)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if requests == 0 {
+		t.Fatal("expected at least one request")
+	}
+	if len(prices) != 1 {
+		t.Fatalf("len(prices) = %d, want 1", len(prices))
+	}
+	if !prices[0].Time.Equal(start) {
+		t.Errorf("Time = %v, want %v", prices[0].Time, start)
+	}
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Request:    &http.Request{URL: &url.URL{}},
+	}
+}
@@ -0,0 +1,48 @@
+package pkg
+
+//VolumeTier is one row of a tiered fee schedule: accounts with at least Volume30d of trailing
+//30-day traded notional pay MakerBps/TakerBps instead of the schedule's base rate.
+type VolumeTier struct {
+	Volume30d float64
+	MakerBps  float64
+	TakerBps  float64
+}
+
+//FeeSchedule computes trading fees as basis points of notional, optionally tiered by
+//trailing 30-day volume. BinanceUSDTMFeeSchedule/BybitInverseFeeSchedule in
+//exchange_presets.go provide ready-made schedules for those exchanges.
+type FeeSchedule struct {
+	MakerBps float64
+	TakerBps float64
+	//Tiers, if set, are checked in descending Volume30d order; the first tier the account's
+	//volume meets or exceeds wins over MakerBps/TakerBps.
+	Tiers []VolumeTier
+}
+
+//Fee returns the fee owed on notional given whether the fill was a maker or taker fill and
+//the account's trailing 30-day volume.
+func (schedule FeeSchedule) Fee(notional float64, isMaker bool, volume30d float64) float64 {
+	maker, taker := schedule.ratesFor(volume30d)
+	rate := taker
+	if isMaker {
+		rate = maker
+	}
+	return notional * rate / 10000
+}
+
+//ratesFor returns the maker/taker bps that apply at volume30d.
+func (schedule FeeSchedule) ratesFor(volume30d float64) (maker, taker float64) {
+	maker, taker = schedule.MakerBps, schedule.TakerBps
+
+	var bestTier *VolumeTier
+	for i, tier := range schedule.Tiers {
+		if volume30d >= tier.Volume30d && (bestTier == nil || tier.Volume30d > bestTier.Volume30d) {
+			bestTier = &schedule.Tiers[i]
+		}
+	}
+	if bestTier != nil {
+		maker, taker = bestTier.MakerBps, bestTier.TakerBps
+	}
+
+	return maker, taker
+}
@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+//FundingPayment is a single funding rate observation: longs pay shorts (or vice versa)
+//Rate * position notional at Time.
+type FundingPayment struct {
+	Time time.Time
+	Rate float64
+}
+
+//FundingSchedule is a time-ordered series of funding rate observations, typically loaded
+//from an exchange's funding rate history CSV/API export via LoadFundingScheduleFromCSV.
+type FundingSchedule struct {
+	Payments []FundingPayment
+}
+
+//RateAt returns the rate of the most recent payment at or before t, or 0 if t precedes
+//every recorded payment.
+func (schedule *FundingSchedule) RateAt(t time.Time) float64 {
+	payment, ok := schedule.PaymentAt(t)
+	if !ok {
+		return 0
+	}
+	return payment.Rate
+}
+
+//PaymentAt returns the most recent payment at or before t, and whether one exists. Callers
+//that apply funding on every tick (rather than just reading the current rate) should track
+//the returned payment's Time themselves and only charge it once per distinct payment.
+func (schedule *FundingSchedule) PaymentAt(t time.Time) (FundingPayment, bool) {
+	payments := schedule.Payments
+	index := sort.Search(len(payments), func(i int) bool {
+		return payments[i].Time.After(t)
+	})
+	if index == 0 {
+		return FundingPayment{}, false
+	}
+	return payments[index-1], true
+}
+
+//LoadFundingScheduleFromCSV reads a "time,rate" CSV (time in RFC3339) into a FundingSchedule,
+//sorted by time.
+func LoadFundingScheduleFromCSV(path string) (*FundingSchedule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("funding schedule: opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("funding schedule: reading header of %s: %w", path, err)
+	}
+
+	var payments []FundingPayment
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("funding schedule: reading %s: %w", path, err)
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, line[0])
+		if err != nil {
+			return nil, fmt.Errorf("funding schedule: parsing time %q in %s: %w", line[0], path, err)
+		}
+		rate, err := strToFloat(line[1])
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, FundingPayment{Time: timestamp, Rate: rate})
+	}
+
+	sort.Slice(payments, func(i, j int) bool { return payments[i].Time.Before(payments[j].Time) })
+	return &FundingSchedule{Payments: payments}, nil
+}
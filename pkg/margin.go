@@ -0,0 +1,87 @@
+package pkg
+
+//MarginModel computes the maintenance margin requirement and liquidation price for a
+//leveraged Position. IsolatedMarginModel and CrossMarginModel are the two modes real
+//exchanges offer; BinanceUSDTMMarginModel/BybitInverseMarginModel in exchange_presets.go
+//wrap them with the constants each exchange actually uses.
+type MarginModel interface {
+	//LiquidationPrice returns the mark price at which position gets force-closed.
+	LiquidationPrice(position *Position) float64
+}
+
+//IsolatedMarginModel computes liquidation price from a single position's own margin and
+//leverage, independent of any other position the account holds.
+//
+//	liq = entry * (1 - 1/leverage + mmr)   for longs
+//	liq = entry * (1 + 1/leverage - mmr)   for shorts
+type IsolatedMarginModel struct {
+	MaintenanceMarginRate float64 //mmr, e.g. 0.004 for 0.4%
+}
+
+//LiquidationPrice implements MarginModel.
+func (m IsolatedMarginModel) LiquidationPrice(position *Position) float64 {
+	leverage := float64(position.Leverage)
+	if leverage == 0 {
+		return 0
+	}
+
+	if position.Direction == Short {
+		return position.EntryPrice * (1 + 1/leverage - m.MaintenanceMarginRate)
+	}
+	return position.EntryPrice * (1 - 1/leverage + m.MaintenanceMarginRate)
+}
+
+//CrossMarginModel computes liquidation price from the combined margin backing every
+//position sharing the same account, so a healthy position can subsidize a losing one
+//until the account's total margin is exhausted.
+type CrossMarginModel struct {
+	MaintenanceMarginRate float64
+	//Positions is the full set of positions sharing this account's margin pool.
+	Positions []*Position
+}
+
+//LiquidationPrice implements MarginModel. It solves for the mark price at which the pooled
+//margin of every position in Positions, plus every other tracked position's current PNL and
+//this position's own PNL, falls to the account's total maintenance margin requirement.
+func (m CrossMarginModel) LiquidationPrice(position *Position) float64 {
+	leverage := float64(position.Leverage)
+	if leverage == 0 {
+		return 0
+	}
+
+	var pooledMargin, otherUnrealizedPNL, maintenanceMargin float64
+	for _, other := range m.Positions {
+		pooledMargin += other.Margin
+		maintenanceMargin += other.Size * m.MaintenanceMarginRate
+		if other != position {
+			otherUnrealizedPNL += other.UnrealizedPNL
+		}
+	}
+
+	//Available buffer, in price terms, before the pool's equity hits its maintenance floor.
+	buffer := (pooledMargin + otherUnrealizedPNL - maintenanceMargin) / position.Size
+
+	if position.Direction == Short {
+		return position.EntryPrice * (1 + buffer)
+	}
+	return position.EntryPrice * (1 - buffer)
+}
+
+//IsLiquidated reports whether mark has crossed position's LiquidationPrice.
+func IsLiquidated(position *Position, mark float64) bool {
+	if position.LiquidationPrice == 0 {
+		return false
+	}
+	if position.Direction == Short {
+		return mark >= position.LiquidationPrice
+	}
+	return mark <= position.LiquidationPrice
+}
+
+//UnrealizedPNL computes a position's mark-to-market PNL at the given mark price.
+func UnrealizedPNL(position *Position, mark float64) float64 {
+	if position.Direction == Short {
+		return position.Size * (position.EntryPrice - mark)
+	}
+	return position.Size * (mark - position.EntryPrice)
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func TestIsolatedMarginModelLiquidationPrice(t *testing.T) {
+	model := IsolatedMarginModel{MaintenanceMarginRate: 0.004}
+
+	long := &Position{Direction: Long, EntryPrice: 100, Leverage: 10}
+	if got, want := model.LiquidationPrice(long), 100*(1-1.0/10+0.004); math.Abs(got-want) > epsilon {
+		t.Errorf("long liquidation price = %v, want %v", got, want)
+	}
+
+	short := &Position{Direction: Short, EntryPrice: 100, Leverage: 10}
+	if got, want := model.LiquidationPrice(short), 100*(1+1.0/10-0.004); math.Abs(got-want) > epsilon {
+		t.Errorf("short liquidation price = %v, want %v", got, want)
+	}
+
+	zeroLeverage := &Position{Direction: Long, EntryPrice: 100}
+	if got := model.LiquidationPrice(zeroLeverage); got != 0 {
+		t.Errorf("zero leverage liquidation price = %v, want 0", got)
+	}
+}
+
+func TestIsLiquidated(t *testing.T) {
+	long := &Position{Direction: Long, LiquidationPrice: 90}
+	if IsLiquidated(long, 91) {
+		t.Error("long should not be liquidated above its liquidation price")
+	}
+	if !IsLiquidated(long, 90) {
+		t.Error("long should be liquidated at its liquidation price")
+	}
+
+	short := &Position{Direction: Short, LiquidationPrice: 110}
+	if IsLiquidated(short, 109) {
+		t.Error("short should not be liquidated below its liquidation price")
+	}
+	if !IsLiquidated(short, 110) {
+		t.Error("short should be liquidated at its liquidation price")
+	}
+
+	unset := &Position{Direction: Long}
+	if IsLiquidated(unset, 0) {
+		t.Error("a position with no LiquidationPrice set should never be reported as liquidated")
+	}
+}
+
+func TestUnrealizedPNL(t *testing.T) {
+	long := &Position{Direction: Long, Size: 2, EntryPrice: 100}
+	if got, want := UnrealizedPNL(long, 110), 20.0; got != want {
+		t.Errorf("long UnrealizedPNL = %v, want %v", got, want)
+	}
+
+	short := &Position{Direction: Short, Size: 2, EntryPrice: 100}
+	if got, want := UnrealizedPNL(short, 110), -20.0; got != want {
+		t.Errorf("short UnrealizedPNL = %v, want %v", got, want)
+	}
+}
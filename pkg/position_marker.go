@@ -0,0 +1,124 @@
+package pkg
+
+import "time"
+
+//PositionMarker implements TickObserver: on every nextValues tick it marks every tracked
+//position to market, deducts any funding due, and liquidates positions whose mark price has
+//crossed their MarginModel-computed LiquidationPrice. Register it with
+//DataHandler.SetTickObserver to wire leverage, funding and liquidation into a backtest.
+type PositionMarker struct {
+	margin  MarginModel
+	fees    FeeSchedule
+	funding *FundingSchedule
+
+	open             []*Position
+	lastFundingPay   map[*Position]time.Time
+	onLiquidation    func(*Position, float64)
+	accountVolume30d float64
+}
+
+//NewPositionMarker returns a PositionMarker using margin for liquidation pricing, fees for
+//fee accounting, and funding for periodic funding payments (nil disables funding).
+func NewPositionMarker(margin MarginModel, fees FeeSchedule, funding *FundingSchedule) *PositionMarker {
+	return &PositionMarker{
+		margin:         margin,
+		fees:           fees,
+		funding:        funding,
+		lastFundingPay: make(map[*Position]time.Time),
+	}
+}
+
+//OnLiquidation registers a callback invoked with the position and mark price whenever
+//OnTick force-closes it.
+func (marker *PositionMarker) OnLiquidation(callback func(position *Position, mark float64)) {
+	marker.onLiquidation = callback
+}
+
+//SetAccountVolume updates the trailing 30-day traded volume marker.fees uses to pick a fee
+//tier for every fee charged from here on (Track, Close, and forced liquidations). Callers
+//tracking their own rolling volume should call this as it changes; it defaults to 0 (the
+//schedule's base rate) until set.
+func (marker *PositionMarker) SetAccountVolume(volume30d float64) {
+	marker.accountVolume30d = volume30d
+}
+
+//Track starts marking position to market on every subsequent tick, charging its opening fee
+//up front. isMaker selects the maker or taker rate from marker.fees for that fee.
+func (marker *PositionMarker) Track(position *Position, isMaker bool) {
+	position.TotalFeePaid += marker.fees.Fee(position.Size, isMaker, marker.accountVolume30d)
+	marker.open = append(marker.open, position)
+}
+
+//Close force-closes position at closePrice for a reason other than liquidation (e.g. the
+//strategy's own stoploss/take-profit or a manual exit), charging its closing fee and removing
+//it from tracking. isMaker selects the maker or taker rate from marker.fees for that fee.
+//Every tracked position that isn't force-closed by OnTick's liquidation check must be closed
+//through this method, or it leaks in marker.open and lastFundingPay forever.
+func (marker *PositionMarker) Close(position *Position, closePrice float64, isMaker bool) {
+	fee := marker.fees.Fee(position.Size, isMaker, marker.accountVolume30d)
+	position.ClosePrice = closePrice
+	position.TotalFeePaid += fee
+	position.RealizedPNL = UnrealizedPNL(position, closePrice) - fee
+	position.UnrealizedPNL = 0
+	marker.untrack(position)
+}
+
+//untrack removes position from the open set and its funding bookkeeping.
+func (marker *PositionMarker) untrack(position *Position) {
+	delete(marker.lastFundingPay, position)
+	stillOpen := marker.open[:0]
+	for _, open := range marker.open {
+		if open != position {
+			stillOpen = append(stillOpen, open)
+		}
+	}
+	marker.open = stillOpen
+}
+
+//OnTick implements TickObserver.
+func (marker *PositionMarker) OnTick(data *AggregatedDataPoints) {
+	latest := data.Latest()
+	mark := latest.Close
+
+	var stillOpen []*Position
+	for _, position := range marker.open {
+		position.LiquidationPrice = marker.margin.LiquidationPrice(position)
+		position.UnrealizedPNL = UnrealizedPNL(position, mark)
+
+		if marker.funding != nil {
+			if payment, ok := marker.funding.PaymentAt(latest.Time); ok && marker.lastFundingPay[position] != payment.Time {
+				fee := payment.Rate * position.Size
+				if position.Direction == Short {
+					fee = -fee
+				}
+				position.TotalFeePaid += fee
+				position.UnrealizedPNL -= fee
+				marker.lastFundingPay[position] = payment.Time
+			}
+		}
+
+		if IsLiquidated(position, mark) {
+			marker.liquidate(position, mark)
+			continue
+		}
+
+		stillOpen = append(stillOpen, position)
+	}
+	marker.open = stillOpen
+}
+
+//liquidate force-closes position at mark, crystallizing its unrealized PNL as realized loss
+//and charging its closing fee. A forced liquidation always takes liquidity, so it's always
+//charged the taker rate.
+func (marker *PositionMarker) liquidate(position *Position, mark float64) {
+	fee := marker.fees.Fee(position.Size, false, marker.accountVolume30d)
+	position.ClosePrice = mark
+	position.TotalFeePaid += fee
+	position.RealizedPNL = UnrealizedPNL(position, mark) - fee
+	position.UnrealizedPNL = 0
+	delete(marker.lastFundingPay, position)
+
+	if marker.onLiquidation != nil {
+		marker.onLiquidation(position, mark)
+	}
+}
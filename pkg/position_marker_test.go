@@ -0,0 +1,85 @@
+package pkg
+
+import "testing"
+
+func TestTrackChargesOpeningFee(t *testing.T) {
+	fees := FeeSchedule{MakerBps: 2, TakerBps: 4}
+	marker := NewPositionMarker(IsolatedMarginModel{MaintenanceMarginRate: 0.004}, fees, nil)
+
+	position := &Position{Direction: Long, Size: 1000, EntryPrice: 100, Leverage: 10}
+	marker.Track(position, false)
+
+	if want := fees.Fee(1000, false, 0); position.TotalFeePaid != want {
+		t.Errorf("TotalFeePaid after Track = %v, want %v", position.TotalFeePaid, want)
+	}
+}
+
+func TestTrackChargesMakerRateForMakerFills(t *testing.T) {
+	fees := FeeSchedule{MakerBps: 2, TakerBps: 4}
+	marker := NewPositionMarker(IsolatedMarginModel{MaintenanceMarginRate: 0.004}, fees, nil)
+
+	position := &Position{Direction: Long, Size: 1000, EntryPrice: 100, Leverage: 10}
+	marker.Track(position, true)
+
+	if want := fees.Fee(1000, true, 0); position.TotalFeePaid != want {
+		t.Errorf("TotalFeePaid after a maker Track = %v, want %v", position.TotalFeePaid, want)
+	}
+}
+
+func TestSetAccountVolumeReachesTieredFees(t *testing.T) {
+	fees := FeeSchedule{
+		MakerBps: 2,
+		TakerBps: 4,
+		Tiers:    []VolumeTier{{Volume30d: 1_000_000, MakerBps: 1, TakerBps: 2}},
+	}
+	marker := NewPositionMarker(IsolatedMarginModel{MaintenanceMarginRate: 0.004}, fees, nil)
+	marker.SetAccountVolume(2_000_000)
+
+	position := &Position{Direction: Long, Size: 1000, EntryPrice: 100, Leverage: 10}
+	marker.Track(position, false)
+
+	if want := fees.Fee(1000, false, 2_000_000); position.TotalFeePaid != want {
+		t.Errorf("TotalFeePaid after Track with tiered volume = %v, want %v", position.TotalFeePaid, want)
+	}
+}
+
+func TestCloseChargesFeeAndStopsTracking(t *testing.T) {
+	fees := FeeSchedule{MakerBps: 2, TakerBps: 4}
+	marker := NewPositionMarker(IsolatedMarginModel{MaintenanceMarginRate: 0.004}, fees, nil)
+
+	position := &Position{Direction: Long, Size: 1000, EntryPrice: 100, Leverage: 10}
+	marker.Track(position, false)
+	openingFee := position.TotalFeePaid
+
+	marker.Close(position, 110, false)
+
+	closingFee := fees.Fee(1000, false, 0)
+	if want := openingFee + closingFee; position.TotalFeePaid != want {
+		t.Errorf("TotalFeePaid after Close = %v, want %v", position.TotalFeePaid, want)
+	}
+	if want := UnrealizedPNL(position, 110) - closingFee; position.RealizedPNL != want {
+		t.Errorf("RealizedPNL after Close = %v, want %v", position.RealizedPNL, want)
+	}
+	if len(marker.open) != 0 {
+		t.Errorf("marker.open after Close = %v, want empty", marker.open)
+	}
+}
+
+func TestOnTickLiquidatesAndStopsTracking(t *testing.T) {
+	marker := NewPositionMarker(IsolatedMarginModel{MaintenanceMarginRate: 0.004}, FeeSchedule{}, nil)
+
+	position := &Position{Direction: Long, Size: 1000, EntryPrice: 100, Leverage: 10}
+	marker.Track(position, false)
+
+	liquidated := false
+	marker.OnLiquidation(func(p *Position, mark float64) { liquidated = true })
+
+	marker.OnTick(&AggregatedDataPoints{datapoints: []DataPoint{{Close: 50}}})
+
+	if !liquidated {
+		t.Fatal("expected position to be liquidated when mark crosses its liquidation price")
+	}
+	if len(marker.open) != 0 {
+		t.Errorf("marker.open after liquidation = %v, want empty", marker.open)
+	}
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"time"
+
+	"github.com/victorl2/kate-backtest/pkg"
+)
+
+//ReportCollector implements pkg.TickObserver, accumulating the equity curve and closed
+//positions needed to build a SummaryReport while a backtest runs. Register it with
+//DataHandler.SetTickObserver so unrealized PnL is tracked alongside realized trades.
+type ReportCollector struct {
+	startingEquity float64
+	openPositions  []*pkg.Position
+	closed         []ClosedPosition
+	realizedPNL    float64
+	equity         []EquityPoint
+}
+
+//NewReportCollector returns a ReportCollector seeded with startingEquity.
+func NewReportCollector(startingEquity float64) *ReportCollector {
+	return &ReportCollector{startingEquity: startingEquity}
+}
+
+//TrackPosition registers an open position so its UnrealizedPNL is included in the equity
+//curve on every subsequent tick.
+func (c *ReportCollector) TrackPosition(position *pkg.Position) {
+	c.openPositions = append(c.openPositions, position)
+}
+
+//RecordClose moves a position from the open set into the closed trade history used for
+//SummaryReport's trade statistics.
+func (c *ReportCollector) RecordClose(position *pkg.Position, openedAt, closedAt time.Time) {
+	for i, open := range c.openPositions {
+		if open == position {
+			c.openPositions = append(c.openPositions[:i], c.openPositions[i+1:]...)
+			break
+		}
+	}
+	c.closed = append(c.closed, ClosedPosition{Position: *position, OpenedAt: openedAt, ClosedAt: closedAt})
+	c.realizedPNL += position.RealizedPNL
+}
+
+//OnTick implements pkg.TickObserver: it samples total equity (starting equity plus realized
+//and unrealized PnL of every tracked position) at the timestamp of the tick's latest candle.
+func (c *ReportCollector) OnTick(data *pkg.AggregatedDataPoints) {
+	equity := c.startingEquity + c.realizedPNL
+	for _, open := range c.openPositions {
+		equity += open.UnrealizedPNL
+	}
+
+	c.equity = append(c.equity, EquityPoint{Time: data.Latest().Time, Equity: equity})
+}
+
+//Build produces the SummaryReport for everything observed so far.
+func (c *ReportCollector) Build(riskFreeRate float64) *SummaryReport {
+	return NewSummaryReport(c.closed, c.equity, riskFreeRate)
+}
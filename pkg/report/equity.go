@@ -0,0 +1,37 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+//WriteEquityCSV writes the per-bar equity/PnL time series as "time,equity,pnl" rows to w,
+//one row per EquityPoint collected during the backtest.
+func (report *SummaryReport) WriteEquityCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"time", "equity", "pnl"}); err != nil {
+		return err
+	}
+
+	startingEquity := 0.0
+	if len(report.Equity) > 0 {
+		startingEquity = report.Equity[0].Equity
+	}
+
+	for _, point := range report.Equity {
+		row := []string{
+			point.Time.Format(time.RFC3339),
+			fmt.Sprintf("%f", point.Equity),
+			fmt.Sprintf("%f", point.Equity-startingEquity),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strconv"
+)
+
+var templateFuncs = template.FuncMap{"mul100": func(v float64) float64 { return v * 100 }}
+
+//reportTemplate renders a self-contained HTML page: the summary stats table plus an inline
+//SVG plot of the equity curve and its drawdown, built from points computed in Go so no JS
+//charting library is required.
+var reportTemplate = template.Must(template.New("report").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backtest Summary Report</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+table { border-collapse: collapse; }
+td, th { padding: 0.25rem 0.75rem; border: 1px solid #ccc; text-align: right; }
+svg { border: 1px solid #ccc; }
+</style>
+</head>
+<body>
+<h1>Backtest Summary Report</h1>
+<table>
+<tr><th>Total Trades</th><td>{{.TotalTrades}}</td></tr>
+<tr><th>Win Rate</th><td>{{printf "%.2f%%" (mul100 .WinRate)}}</td></tr>
+<tr><th>Profit Factor</th><td>{{printf "%.2f" .ProfitFactor}}</td></tr>
+<tr><th>Expectancy</th><td>{{printf "%.2f" .Expectancy}}</td></tr>
+<tr><th>Average Win</th><td>{{printf "%.2f" .AverageWin}}</td></tr>
+<tr><th>Average Loss</th><td>{{printf "%.2f" .AverageLoss}}</td></tr>
+<tr><th>Sharpe</th><td>{{printf "%.2f" .Sharpe}}</td></tr>
+<tr><th>Sortino</th><td>{{printf "%.2f" .Sortino}}</td></tr>
+<tr><th>Calmar</th><td>{{printf "%.2f" .Calmar}}</td></tr>
+<tr><th>Max Drawdown</th><td>{{printf "%.2f%%" (mul100 .MaxDrawdown)}}</td></tr>
+<tr><th>Max Drawdown Duration</th><td>{{.MaxDrawdownDuration}}</td></tr>
+<tr><th>CAGR</th><td>{{printf "%.2f%%" (mul100 .CAGR)}}</td></tr>
+</table>
+
+<h2>Equity Curve</h2>
+<svg width="{{.Plot.Width}}" height="{{.Plot.Height}}" viewBox="0 0 {{.Plot.Width}} {{.Plot.Height}}">
+<polyline fill="none" stroke="#2a6fdb" stroke-width="2" points="{{.Plot.EquityPoints}}" />
+</svg>
+
+<h2>Drawdown</h2>
+<svg width="{{.Plot.Width}}" height="{{.Plot.Height}}" viewBox="0 0 {{.Plot.Width}} {{.Plot.Height}}">
+<polyline fill="none" stroke="#db2a2a" stroke-width="2" points="{{.Plot.DrawdownPoints}}" />
+</svg>
+</body>
+</html>
+`))
+
+//equityPlot holds the SVG-ready coordinates derived from a SummaryReport's equity curve.
+type equityPlot struct {
+	Width, Height  int
+	EquityPoints   string
+	DrawdownPoints string
+}
+
+//htmlView is the data passed to reportTemplate.
+type htmlView struct {
+	*SummaryReport
+	Plot equityPlot
+}
+
+//WriteHTML renders a self-contained HTML page with the summary stats table and equity/
+//drawdown plots to w.
+func (report *SummaryReport) WriteHTML(w io.Writer) error {
+	view := htmlView{SummaryReport: report, Plot: buildEquityPlot(report.Equity, 800, 200)}
+	return reportTemplate.Execute(w, view)
+}
+
+//buildEquityPlot normalizes an equity curve (and its running drawdown) into width x height
+//SVG polyline coordinates.
+func buildEquityPlot(equity []EquityPoint, width, height int) equityPlot {
+	if len(equity) == 0 {
+		return equityPlot{Width: width, Height: height}
+	}
+
+	minEquity, maxEquity := equity[0].Equity, equity[0].Equity
+	peak := equity[0].Equity
+	drawdowns := make([]float64, len(equity))
+	minDrawdown := 0.0
+
+	for i, point := range equity {
+		if point.Equity < minEquity {
+			minEquity = point.Equity
+		}
+		if point.Equity > maxEquity {
+			maxEquity = point.Equity
+		}
+		if point.Equity > peak {
+			peak = point.Equity
+		}
+		if peak != 0 {
+			drawdowns[i] = (point.Equity - peak) / peak
+		}
+		if drawdowns[i] < minDrawdown {
+			minDrawdown = drawdowns[i]
+		}
+	}
+
+	return equityPlot{
+		Width:          width,
+		Height:         height,
+		EquityPoints:   plotPoints(equity, minEquity, maxEquity, width, height),
+		DrawdownPoints: plotDrawdownPoints(drawdowns, minDrawdown, width, height),
+	}
+}
+
+func plotPoints(equity []EquityPoint, min, max float64, width, height int) string {
+	points := ""
+	span := max - min
+	for i, point := range equity {
+		x := float64(i) / float64(maxInt(len(equity)-1, 1)) * float64(width)
+		y := float64(height)
+		if span != 0 {
+			y = float64(height) - (point.Equity-min)/span*float64(height)
+		}
+		points += formatPoint(x, y)
+	}
+	return points
+}
+
+func plotDrawdownPoints(drawdowns []float64, min float64, width, height int) string {
+	points := ""
+	for i, drawdown := range drawdowns {
+		x := float64(i) / float64(maxInt(len(drawdowns)-1, 1)) * float64(width)
+		y := float64(height)
+		if min != 0 {
+			y = (drawdown / min) * float64(height)
+		}
+		points += formatPoint(x, y)
+	}
+	return points
+}
+
+func formatPoint(x, y float64) string {
+	return strconv.FormatFloat(x, 'f', 2, 64) + "," + strconv.FormatFloat(y, 'f', 2, 64) + " "
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
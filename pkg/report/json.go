@@ -0,0 +1,14 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+//WriteJSON writes the SummaryReport (excluding the raw equity curve, see WriteEquityCSV) as
+//indented JSON to w.
+func (report *SummaryReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,276 @@
+//Package report turns the stream of closed positions and per-tick equity produced by a
+//backtest into a SummaryReport, plus JSON and HTML renderings of it.
+package report
+
+import (
+	"math"
+	"time"
+
+	"github.com/victorl2/kate-backtest/pkg"
+)
+
+//ClosedPosition pairs a closed pkg.Position with the times it was opened and closed, which
+//pkg.Position itself doesn't track.
+type ClosedPosition struct {
+	pkg.Position
+	OpenedAt time.Time
+	ClosedAt time.Time
+}
+
+//EquityPoint is one sample of a backtest's equity curve.
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+//Breakdown holds the subset of SummaryReport stats that make sense per symbol or per side.
+type Breakdown struct {
+	TotalTrades int
+	WinRate     float64
+	RealizedPNL float64
+}
+
+//SummaryReport is the full set of trade and equity-curve statistics computed for a backtest.
+type SummaryReport struct {
+	TotalTrades          int
+	WinRate              float64
+	ProfitFactor         float64
+	Expectancy           float64
+	AverageWin           float64
+	AverageLoss          float64
+	Sharpe               float64
+	Sortino              float64
+	Calmar               float64
+	MaxDrawdown          float64
+	MaxDrawdownDuration  time.Duration
+	CAGR                 float64
+	BySymbol             map[string]*Breakdown
+	BySide               map[pkg.Direction]*Breakdown
+	Equity               []EquityPoint `json:"-"`
+}
+
+//NewSummaryReport computes a SummaryReport from every position closed during a backtest and
+//its per-tick equity curve. riskFreeRate is the risk-free return over the whole equity curve:
+//it is split evenly across the curve's periods (with no annualization) and subtracted from
+//each period's return before computing Sharpe and Sortino.
+func NewSummaryReport(closed []ClosedPosition, equity []EquityPoint, riskFreeRate float64) *SummaryReport {
+	report := &SummaryReport{
+		TotalTrades: len(closed),
+		BySymbol:    make(map[string]*Breakdown),
+		BySide:      make(map[pkg.Direction]*Breakdown),
+		Equity:      equity,
+	}
+
+	var wins, losses int
+	var sumWin, sumLoss, sumPNL float64
+
+	for _, position := range closed {
+		pnl := position.RealizedPNL
+
+		symbol := report.BySymbol[position.Symbol]
+		if symbol == nil {
+			symbol = &Breakdown{}
+			report.BySymbol[position.Symbol] = symbol
+		}
+		symbol.TotalTrades++
+		symbol.RealizedPNL += pnl
+
+		side := report.BySide[position.Direction]
+		if side == nil {
+			side = &Breakdown{}
+			report.BySide[position.Direction] = side
+		}
+		side.TotalTrades++
+		side.RealizedPNL += pnl
+
+		sumPNL += pnl
+		if pnl >= 0 {
+			wins++
+			sumWin += pnl
+		} else {
+			losses++
+			sumLoss += pnl
+		}
+	}
+
+	if report.TotalTrades > 0 {
+		report.WinRate = float64(wins) / float64(report.TotalTrades)
+	}
+	if wins > 0 {
+		report.AverageWin = sumWin / float64(wins)
+	}
+	if losses > 0 {
+		report.AverageLoss = sumLoss / float64(losses)
+	}
+	if sumLoss != 0 {
+		report.ProfitFactor = sumWin / math.Abs(sumLoss)
+	}
+	report.Expectancy = report.WinRate*report.AverageWin + (1-report.WinRate)*report.AverageLoss
+
+	for symbol, breakdown := range report.BySymbol {
+		breakdown.WinRate = winRate(closed, func(p ClosedPosition) bool { return p.Symbol == symbol })
+	}
+	for side, breakdown := range report.BySide {
+		breakdown.WinRate = winRate(closed, func(p ClosedPosition) bool { return p.Direction == side })
+	}
+
+	returns := equityReturns(equity)
+	report.Sharpe = sharpeRatio(returns, riskFreeRate)
+	report.Sortino = sortinoRatio(returns, riskFreeRate)
+	report.MaxDrawdown, report.MaxDrawdownDuration = maxDrawdown(equity)
+	report.CAGR = cagr(equity)
+	if report.MaxDrawdown != 0 {
+		report.Calmar = report.CAGR / math.Abs(report.MaxDrawdown)
+	}
+
+	return report
+}
+
+//winRate computes the fraction of positions matching filter that closed with non-negative PNL.
+func winRate(closed []ClosedPosition, filter func(ClosedPosition) bool) float64 {
+	var total, wins int
+	for _, position := range closed {
+		if !filter(position) {
+			continue
+		}
+		total++
+		if position.RealizedPNL >= 0 {
+			wins++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(wins) / float64(total)
+}
+
+//equityReturns converts an equity curve into a series of simple period-over-period returns.
+func equityReturns(equity []EquityPoint) []float64 {
+	if len(equity) < 2 {
+		return nil
+	}
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		prev := equity[i-1].Equity
+		if prev == 0 {
+			continue
+		}
+		returns = append(returns, (equity[i].Equity-prev)/prev)
+	}
+	return returns
+}
+
+//sharpeRatio is the mean excess return over its standard deviation.
+func sharpeRatio(returns []float64, riskFreeRate float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	periodRate := riskFreeRate / float64(len(returns))
+	mean, stddev := meanAndStdDev(returns, periodRate)
+	if stddev == 0 {
+		return 0
+	}
+	return mean / stddev
+}
+
+//sortinoRatio is like sharpeRatio but only penalizes downside deviation.
+func sortinoRatio(returns []float64, riskFreeRate float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	periodRate := riskFreeRate / float64(len(returns))
+
+	var sum, downsideSumSq float64
+	var downsideCount int
+	for _, r := range returns {
+		excess := r - periodRate
+		sum += excess
+		if excess < 0 {
+			downsideSumSq += excess * excess
+			downsideCount++
+		}
+	}
+	mean := sum / float64(len(returns))
+	if downsideCount == 0 {
+		return 0
+	}
+	downsideDeviation := math.Sqrt(downsideSumSq / float64(downsideCount))
+	if downsideDeviation == 0 {
+		return 0
+	}
+	return mean / downsideDeviation
+}
+
+//meanAndStdDev returns the mean excess return (over periodRate) and its population standard deviation.
+func meanAndStdDev(returns []float64, periodRate float64) (mean, stddev float64) {
+	var sum float64
+	for _, r := range returns {
+		sum += r - periodRate
+	}
+	mean = sum / float64(len(returns))
+
+	var sumSq float64
+	for _, r := range returns {
+		diff := (r - periodRate) - mean
+		sumSq += diff * diff
+	}
+	stddev = math.Sqrt(sumSq / float64(len(returns)))
+	return mean, stddev
+}
+
+//maxDrawdown returns the largest peak-to-trough decline in the equity curve and how long it
+//took to recover back to the prior peak (0 if it never recovered by the end of the curve).
+func maxDrawdown(equity []EquityPoint) (float64, time.Duration) {
+	if len(equity) == 0 {
+		return 0, 0
+	}
+
+	peak := equity[0].Equity
+	var maxDD float64
+	var troughIndex int
+	var troughPeak float64
+
+	for i, point := range equity {
+		if point.Equity > peak {
+			peak = point.Equity
+		}
+		if peak == 0 {
+			continue
+		}
+		if drawdown := (point.Equity - peak) / peak; drawdown < maxDD {
+			maxDD = drawdown
+			troughIndex = i
+			troughPeak = peak
+		}
+	}
+	if maxDD == 0 {
+		return 0, 0
+	}
+
+	troughTime := equity[troughIndex].Time
+	for _, point := range equity[troughIndex+1:] {
+		if point.Equity >= troughPeak {
+			return maxDD, point.Time.Sub(troughTime)
+		}
+	}
+
+	return maxDD, 0
+}
+
+//cagr is the compound annual growth rate implied by the first and last equity curve points.
+func cagr(equity []EquityPoint) float64 {
+	if len(equity) < 2 {
+		return 0
+	}
+	start, end := equity[0], equity[len(equity)-1]
+	if start.Equity <= 0 {
+		return 0
+	}
+
+	years := end.Time.Sub(start.Time).Hours() / (24 * 365)
+	if years <= 0 {
+		return 0
+	}
+
+	return math.Pow(end.Equity/start.Equity, 1/years) - 1
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func equityAt(start time.Time, offsets ...float64) []EquityPoint {
+	points := make([]EquityPoint, len(offsets))
+	for i, equity := range offsets {
+		points[i] = EquityPoint{Time: start.Add(time.Duration(i) * time.Hour), Equity: equity}
+	}
+	return points
+}
+
+func TestMaxDrawdownRecovers(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	//peak 100 at t0, trough 80 at t2 (a -20% drawdown), recovers to 100 at t5.
+	equity := equityAt(start, 100, 90, 80, 85, 95, 100)
+
+	dd, duration := maxDrawdown(equity)
+	if want := -0.2; dd != want {
+		t.Errorf("maxDrawdown = %v, want %v", dd, want)
+	}
+	if want := 3 * time.Hour; duration != want {
+		t.Errorf("duration = %v, want %v (trough at t2 to recovery at t5)", duration, want)
+	}
+}
+
+func TestMaxDrawdownNeverRecovers(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	equity := equityAt(start, 100, 90, 80, 85)
+
+	dd, duration := maxDrawdown(equity)
+	if want := -0.2; dd != want {
+		t.Errorf("maxDrawdown = %v, want %v", dd, want)
+	}
+	if duration != 0 {
+		t.Errorf("duration = %v, want 0 (curve ends before recovering)", duration)
+	}
+}
+
+func TestMaxDrawdownFlatCurve(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	equity := equityAt(start, 100, 100, 100)
+
+	dd, duration := maxDrawdown(equity)
+	if dd != 0 {
+		t.Errorf("maxDrawdown = %v, want 0", dd)
+	}
+	if duration != 0 {
+		t.Errorf("duration = %v, want 0", duration)
+	}
+}